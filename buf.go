@@ -1,15 +1,29 @@
 package buffpool
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// ErrPoolReleased is returned by AcquireContext when the pool is released
+// while a caller is waiting for a buffer.
+var ErrPoolReleased = errors.New("buffpool: pool released")
+
+// bufferOwner is whatever a Buffer is handed back to on Release. *Pool[T]
+// satisfies it directly; other owners (e.g. SizedPool's oversize buffers)
+// can implement it without being a Pool at all.
+type bufferOwner[T any] interface {
+	put(b *Buffer[T])
+}
+
 type Buffer[T any] struct {
 	data   []T
 	length int
-	pool   *Pool[T]
+	pool   bufferOwner[T]
 	inUse  int32
 }
 
@@ -48,15 +62,61 @@ type Pool[T any] struct {
 	isInitialized bool
 	isReleased    int32
 	mu            sync.Mutex
+
+	// FlushInterval, if non-zero, starts a background reaper on Init that
+	// periodically shrinks the pool back down to its observed idle low
+	// water mark (minFill). Set before calling Init.
+	FlushInterval time.Duration
+	// Alloc and Free back buffers with something other than the Go heap,
+	// e.g. an mmap region. They default to a plain make([]T, n) and a
+	// no-op respectively. Set before calling Init.
+	Alloc func(n int) ([]T, error)
+	Free  func(data []T) error
+
+	// MaxCount is the high-water cap Acquire/AcquireContext may grow the
+	// pool to on demand once bufCount buffers are all checked out. It
+	// defaults to bufCount (no growth) when left zero on Init. Set
+	// MaxCount == bufCount explicitly for the same fixed-capacity
+	// guarantee the pool has always had.
+	MaxCount int
+
+	// ResetFunc, if set, runs on a buffer on Release, before it re-enters
+	// the channel and after its length has been zeroed. Use it to clear
+	// sensitive data, or to reset a pooled payload that carries its own
+	// state (e.g. calling Reset on a *gzip.Writer stored in a Buffer[T]).
+	ResetFunc func(b *Buffer[T])
+
+	minFill    int32
+	reaperStop chan struct{}
+	done       chan struct{}
+
+	created int64
+	peak    int64
+
+	// held tracks every buffer currently sitting idle in buffers, guarded
+	// by mu. It exists purely to catch a buffer being put back twice (e.g.
+	// a caller bypassing Buffer.Release and calling Pool.put directly, or
+	// misuse under a panic/recover), which would otherwise let two
+	// Acquire callers end up holding the same buffer.
+	held map[*Buffer[T]]struct{}
 }
 
 func NewPool[T any]() *Pool[T] {
 	return &Pool[T]{
 		isInitialized: false,
 		isReleased:    0,
+		done:          make(chan struct{}),
 	}
 }
 
+func defaultAlloc[T any](n int) ([]T, error) {
+	return make([]T, n), nil
+}
+
+func defaultFree[T any](data []T) error {
+	return nil
+}
+
 func (p *Pool[T]) Init(bufCount, bufSize int) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -69,23 +129,104 @@ func (p *Pool[T]) Init(bufCount, bufSize int) error {
 		return fmt.Errorf("invalid buffer count or size")
 	}
 
+	if p.MaxCount == 0 {
+		p.MaxCount = bufCount
+	}
+	if p.MaxCount < bufCount {
+		return fmt.Errorf("MaxCount %d is smaller than bufCount %d", p.MaxCount, bufCount)
+	}
+
+	if p.Alloc == nil {
+		p.Alloc = defaultAlloc[T]
+	}
+	if p.Free == nil {
+		p.Free = defaultFree[T]
+	}
+
 	p.bufCount = bufCount
 	p.bufSize = bufSize
-	p.buffers = make(chan *Buffer[T], bufCount)
+	p.buffers = make(chan *Buffer[T], p.MaxCount)
+	p.held = make(map[*Buffer[T]]struct{}, bufCount)
 
 	for i := 0; i < bufCount; i++ {
-		p.buffers <- &Buffer[T]{
-			data:   make([]T, bufSize),
+		data, err := p.Alloc(bufSize)
+		if err != nil {
+			return fmt.Errorf("allocating buffer %d: %w", i, err)
+		}
+		buf := &Buffer[T]{
+			data:   data,
 			length: 0,
 			pool:   p,
 			inUse:  0,
 		}
+		p.buffers <- buf
+		p.held[buf] = struct{}{}
 	}
 
+	atomic.StoreInt32(&p.minFill, int32(bufCount))
+	atomic.StoreInt64(&p.created, int64(bufCount))
+	atomic.StoreInt64(&p.peak, int64(bufCount))
 	p.isInitialized = true
+
+	if p.FlushInterval > 0 {
+		p.reaperStop = make(chan struct{})
+		go p.reap()
+	}
+
 	return nil
 }
 
+// recordAvailable keeps minFill at the lowest idle buffer count observed
+// since the last reaper tick, so the reaper knows how much headroom it
+// can safely drop without having starved a caller.
+func (p *Pool[T]) recordAvailable() {
+	n := int32(len(p.buffers))
+	for {
+		cur := atomic.LoadInt32(&p.minFill)
+		if n >= cur {
+			return
+		}
+		if atomic.CompareAndSwapInt32(&p.minFill, cur, n) {
+			return
+		}
+	}
+}
+
+// reap runs on its own goroutine while FlushInterval is set. Every tick it
+// drops exactly the minimum number of buffers observed idle since the last
+// tick, freeing their backing storage via Free, then resets minFill to the
+// current available count.
+func (p *Pool[T]) reap() {
+	ticker := time.NewTicker(p.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.reaperStop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			drop := atomic.LoadInt32(&p.minFill)
+		dropLoop:
+			for i := int32(0); i < drop; i++ {
+				select {
+				case buf := <-p.buffers:
+					delete(p.held, buf)
+					p.Free(buf.data)
+					// The buffer is gone for good regardless of whether Free
+					// reported an error, so grow's headroom must shrink with
+					// it or the pool wedges at 0 buffers once MaxCount is hit.
+					atomic.AddInt64(&p.created, -1)
+				default:
+					break dropLoop // nothing left to drop
+				}
+			}
+			atomic.StoreInt32(&p.minFill, int32(len(p.buffers)))
+			p.mu.Unlock()
+		}
+	}
+}
+
 func (p *Pool[T]) BufferChan() <-chan *Buffer[T] {
 	if atomic.LoadInt32(&p.isReleased) == 1 {
 		return nil
@@ -94,6 +235,7 @@ func (p *Pool[T]) BufferChan() <-chan *Buffer[T] {
 	out := make(chan *Buffer[T])
 	go func() {
 		for buf := range p.buffers {
+			p.removeHeld(buf)
 			if atomic.CompareAndSwapInt32(&buf.inUse, 0, 1) {
 				out <- buf
 			} else {
@@ -105,15 +247,35 @@ func (p *Pool[T]) BufferChan() <-chan *Buffer[T] {
 	return out
 }
 
+// removeHeld drops b from the membership set when it's handed out by
+// Acquire, AcquireContext, or BufferChan.
+func (p *Pool[T]) removeHeld(b *Buffer[T]) {
+	p.mu.Lock()
+	delete(p.held, b)
+	p.mu.Unlock()
+}
+
 func (p *Pool[T]) put(b *Buffer[T]) {
 	if atomic.LoadInt32(&p.isReleased) == 1 {
 		return
 	}
 	if atomic.LoadInt32(&b.inUse) == 0 {
+		p.mu.Lock()
+		if _, dup := p.held[b]; dup {
+			p.mu.Unlock()
+			panic("buffpool: buffer put back to the pool twice")
+		}
+		if p.ResetFunc != nil {
+			p.ResetFunc(b)
+		}
 		select {
 		case p.buffers <- b:
 			// Successfully returned to the pool
+			p.held[b] = struct{}{}
+			p.mu.Unlock()
+			p.recordAvailable()
 		default:
+			p.mu.Unlock()
 			panic("Attempting to return a buffer to a full pool")
 		}
 	} else {
@@ -126,7 +288,13 @@ func (p *Pool[T]) Acquire() (*Buffer[T], bool) {
 		return nil, false
 	}
 	select {
-	case buf := <-p.buffers:
+	case buf, ok := <-p.buffers:
+		if !ok {
+			// Release() closed the channel while we were selecting on it.
+			return nil, false
+		}
+		p.removeHeld(buf)
+		p.recordAvailable()
 		if atomic.CompareAndSwapInt32(&buf.inUse, 0, 1) {
 			return buf, true
 		}
@@ -134,8 +302,101 @@ func (p *Pool[T]) Acquire() (*Buffer[T], bool) {
 		p.put(buf)
 		return p.Acquire()
 	default:
+		return p.grow()
+	}
+}
+
+// grow allocates a new buffer beyond bufCount when the pool is empty,
+// provided the pool hasn't already created MaxCount buffers. The returned
+// buffer is handed out already marked in-use; Release routes it through
+// the normal put path like any other buffer.
+func (p *Pool[T]) grow() (*Buffer[T], bool) {
+	for {
+		cur := atomic.LoadInt64(&p.created)
+		if cur >= int64(p.MaxCount) {
+			return nil, false
+		}
+		if atomic.CompareAndSwapInt64(&p.created, cur, cur+1) {
+			break
+		}
+	}
+
+	data, err := p.Alloc(p.bufSize)
+	if err != nil {
+		atomic.AddInt64(&p.created, -1)
 		return nil, false
 	}
+	p.updatePeak()
+
+	return &Buffer[T]{
+		data:  data,
+		pool:  p,
+		inUse: 1,
+	}, true
+}
+
+func (p *Pool[T]) updatePeak() {
+	for {
+		created := atomic.LoadInt64(&p.created)
+		cur := atomic.LoadInt64(&p.peak)
+		if created <= cur {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&p.peak, cur, created) {
+			return
+		}
+	}
+}
+
+// Created returns how many buffers the pool has allocated in total,
+// including any grown beyond bufCount.
+func (p *Pool[T]) Created() int {
+	return int(atomic.LoadInt64(&p.created))
+}
+
+// Peak returns the highest Created has ever reached.
+func (p *Pool[T]) Peak() int {
+	return int(atomic.LoadInt64(&p.peak))
+}
+
+// TryAcquire is an alias for Acquire, named to match the standard library's
+// convention for a non-blocking acquire (e.g. sync.Mutex.TryLock).
+func (p *Pool[T]) TryAcquire() (*Buffer[T], bool) {
+	return p.Acquire()
+}
+
+// AcquireContext blocks until a buffer is available, ctx is cancelled, or
+// the pool is released, whichever happens first.
+func (p *Pool[T]) AcquireContext(ctx context.Context) (*Buffer[T], error) {
+	if atomic.LoadInt32(&p.isReleased) == 1 {
+		return nil, ErrPoolReleased
+	}
+
+	// Try a non-blocking acquire first, which also covers growing the pool
+	// on demand, before falling back to waiting on the channel.
+	if buf, ok := p.Acquire(); ok {
+		return buf, nil
+	}
+
+	select {
+	case buf, ok := <-p.buffers:
+		if !ok {
+			// Release() closed the channel while we were selecting on it.
+			return nil, ErrPoolReleased
+		}
+		p.removeHeld(buf)
+		p.recordAvailable()
+		if atomic.CompareAndSwapInt32(&buf.inUse, 0, 1) {
+			return buf, nil
+		}
+		// If the buffer is somehow already in use, put it back and try again
+		p.put(buf)
+		return p.AcquireContext(ctx)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.done:
+		return nil, ErrPoolReleased
+	}
 }
 
 func (p *Pool[T]) Reset() {
@@ -154,16 +415,27 @@ func (p *Pool[T]) Reset() {
 	for len(p.buffers) > 0 {
 		<-p.buffers
 	}
+	p.held = make(map[*Buffer[T]]struct{}, p.bufCount)
 
 	// Refill the pool
 	for i := 0; i < p.bufCount; i++ {
-		p.buffers <- &Buffer[T]{
-			data:   make([]T, p.bufSize),
+		data, err := p.Alloc(p.bufSize)
+		if err != nil {
+			continue
+		}
+		buf := &Buffer[T]{
+			data:   data,
 			length: 0,
 			pool:   p,
 			inUse:  0,
 		}
+		p.buffers <- buf
+		p.held[buf] = struct{}{}
 	}
+
+	atomic.StoreInt32(&p.minFill, int32(len(p.buffers)))
+	atomic.StoreInt64(&p.created, int64(p.bufCount))
+	atomic.StoreInt64(&p.peak, int64(p.bufCount))
 }
 
 func (p *Pool[T]) Available() int {
@@ -175,6 +447,11 @@ func (p *Pool[T]) Available() int {
 
 func (p *Pool[T]) Release() {
 	if atomic.CompareAndSwapInt32(&p.isReleased, 0, 1) {
+		if p.reaperStop != nil {
+			close(p.reaperStop)
+		}
+		close(p.done)
+
 		p.mu.Lock()
 		defer p.mu.Unlock()
 
@@ -188,5 +465,6 @@ func (p *Pool[T]) Release() {
 		p.bufCount = 0
 		p.bufSize = 0
 		p.isInitialized = false
+		p.held = nil
 	}
 }
@@ -1,6 +1,8 @@
 package buffpool
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -234,6 +236,346 @@ func TestInitReleasedPool(t *testing.T) {
 	}
 }
 
+func TestFlushIntervalShrinksIdleBuffers(t *testing.T) {
+	pool := NewPool[int]()
+	pool.FlushInterval = 20 * time.Millisecond
+	var freed int32
+	pool.Free = func(data []int) error {
+		atomic.AddInt32(&freed, 1)
+		return nil
+	}
+	pool.Init(5, 10)
+
+	// Two buffers never go idle again, so the reaper should only ever be
+	// able to drop the other three.
+	buf1, _ := pool.Acquire()
+	buf2, _ := pool.Acquire()
+
+	time.Sleep(60 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&freed); got != 3 {
+		t.Errorf("Expected reaper to free 3 idle buffers, freed %d", got)
+	}
+	if pool.Available() != 0 {
+		t.Errorf("Expected 0 available buffers after reap, got %d", pool.Available())
+	}
+
+	buf1.Release()
+	buf2.Release()
+	pool.Release()
+}
+
+func TestReapedBuffersCanBeReGrown(t *testing.T) {
+	pool := NewPool[int]()
+	pool.FlushInterval = 20 * time.Millisecond
+	pool.MaxCount = 4
+	pool.Init(4, 10)
+
+	bufs := make([]*Buffer[int], 4)
+	for i := range bufs {
+		var ok bool
+		bufs[i], ok = pool.Acquire()
+		if !ok {
+			t.Fatalf("Failed to acquire buffer %d", i)
+		}
+	}
+	for _, buf := range bufs {
+		buf.Release()
+	}
+
+	// Let the reaper shrink the whole idle pool back down to zero.
+	time.Sleep(60 * time.Millisecond)
+	if pool.Available() != 0 {
+		t.Fatalf("Expected 0 available buffers after reap, got %d", pool.Available())
+	}
+	if pool.Created() != 0 {
+		t.Fatalf("Expected Created() to drop back to 0 after reap, got %d", pool.Created())
+	}
+
+	if _, ok := pool.Acquire(); !ok {
+		t.Error("Expected the pool to grow again after the reaper freed its buffers")
+	}
+}
+
+func TestAllocError(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Alloc = func(n int) ([]int, error) {
+		return nil, fmt.Errorf("boom")
+	}
+	if err := pool.Init(2, 10); err == nil {
+		t.Error("Expected Init to surface an Alloc error, got nil")
+	}
+}
+
+func TestTryAcquire(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+
+	buf, ok := pool.TryAcquire()
+	if !ok {
+		t.Fatal("Failed to acquire buffer")
+	}
+	if _, ok := pool.TryAcquire(); ok {
+		t.Error("Expected TryAcquire to fail on an empty pool")
+	}
+	buf.Release()
+}
+
+func TestAcquireContextSucceedsImmediately(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+
+	buf, err := pool.AcquireContext(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireContext failed: %v", err)
+	}
+	buf.Release()
+}
+
+func TestAcquireContextBlocksUntilRelease(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+
+	buf, _ := pool.Acquire()
+
+	done := make(chan struct{})
+	go func() {
+		b, err := pool.AcquireContext(context.Background())
+		if err != nil {
+			t.Errorf("AcquireContext failed: %v", err)
+		} else {
+			b.Release()
+		}
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	buf.Release()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Timed out waiting for AcquireContext to unblock after release")
+	}
+}
+
+func TestAcquireContextCancellation(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+	pool.Acquire() // drain the only buffer
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := pool.AcquireContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAcquireContextPoolReleased(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+	pool.Acquire() // drain the only buffer
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pool.AcquireContext(context.Background())
+		done <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	pool.Release()
+
+	select {
+	case err := <-done:
+		if err != ErrPoolReleased {
+			t.Errorf("Expected ErrPoolReleased, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Timed out waiting for AcquireContext to unblock after pool release")
+	}
+}
+
+func TestDuplicatePutPanics(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(2, 10)
+
+	buf, _ := pool.Acquire()
+	buf.Release()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic on duplicate put, but no panic occurred")
+		}
+	}()
+
+	// Simulate a caller bypassing Buffer.Release (e.g. after a panic/recover
+	// that left inUse cleared) and smuggling the same buffer back in twice.
+	atomic.StoreInt32(&buf.inUse, 0)
+	pool.put(buf)
+}
+
+func TestDuplicatePutViaBufferChan(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(1, 10)
+
+	bufChan := pool.BufferChan()
+	buf := <-bufChan
+
+	// buf is still legitimately checked out here (BufferChan's CAS left
+	// inUse at 1 and removed it from held, same as Acquire does), so a
+	// direct put without going through Release first must hit the
+	// still-in-use guard. Forcing inUse back to 0 first, like
+	// TestDuplicatePutPanics does, would race BufferChan's own goroutine
+	// for the buffer the instant it's released, which made this test
+	// flaky under -race.
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic when putting a buffer acquired via BufferChan without releasing it first")
+		}
+	}()
+
+	pool.put(buf)
+}
+
+func TestFixedCapacityByDefault(t *testing.T) {
+	pool := NewPool[int]()
+	pool.Init(2, 10)
+
+	pool.Acquire()
+	pool.Acquire()
+	if _, ok := pool.Acquire(); ok {
+		t.Error("Expected Acquire to fail once bufCount buffers are checked out with no MaxCount set")
+	}
+	if pool.Created() != 2 {
+		t.Errorf("Expected Created() == 2, got %d", pool.Created())
+	}
+}
+
+func TestGrowBeyondBufCount(t *testing.T) {
+	pool := NewPool[int]()
+	pool.MaxCount = 4
+	pool.Init(2, 10)
+
+	buf1, _ := pool.Acquire()
+	buf2, _ := pool.Acquire()
+
+	buf3, ok := pool.Acquire()
+	if !ok {
+		t.Fatal("Expected pool to grow past bufCount")
+	}
+	buf4, ok := pool.Acquire()
+	if !ok {
+		t.Fatal("Expected pool to grow up to MaxCount")
+	}
+
+	if _, ok := pool.Acquire(); ok {
+		t.Error("Expected Acquire to fail once MaxCount buffers are checked out")
+	}
+	if pool.Created() != 4 || pool.Peak() != 4 {
+		t.Errorf("Expected Created() == Peak() == 4, got Created()=%d Peak()=%d", pool.Created(), pool.Peak())
+	}
+
+	buf1.Release()
+	buf2.Release()
+	buf3.Release()
+	buf4.Release()
+
+	if pool.Available() != 4 {
+		t.Errorf("Expected all 4 grown buffers to be returned to the pool, got %d", pool.Available())
+	}
+}
+
+func TestAcquireContextGrows(t *testing.T) {
+	pool := NewPool[int]()
+	pool.MaxCount = 2
+	pool.Init(1, 10)
+
+	pool.Acquire()
+
+	buf, err := pool.AcquireContext(context.Background())
+	if err != nil {
+		t.Fatalf("Expected AcquireContext to grow the pool rather than block, got %v", err)
+	}
+	buf.Release()
+}
+
+func TestResetFuncRunsOnRelease(t *testing.T) {
+	pool := NewPool[byte]()
+	var resetCalls int32
+	pool.ResetFunc = func(b *Buffer[byte]) {
+		atomic.AddInt32(&resetCalls, 1)
+		data := b.GetFullData()
+		for i := range data {
+			data[i] = 0
+		}
+	}
+	pool.Init(1, 10)
+
+	buf, _ := pool.Acquire()
+	data := buf.GetFullData()
+	for i := range data {
+		data[i] = 0xFF
+	}
+	buf.Release()
+
+	if atomic.LoadInt32(&resetCalls) != 1 {
+		t.Errorf("Expected ResetFunc to run once, ran %d times", resetCalls)
+	}
+
+	buf, _ = pool.Acquire()
+	for _, v := range buf.GetFullData() {
+		if v != 0 {
+			t.Errorf("Expected buffer to be zeroed by ResetFunc, got %v", buf.GetFullData())
+			break
+		}
+	}
+	buf.Release()
+}
+
+func TestAcquireDuringConcurrentRelease(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		pool := NewPool[int]()
+		pool.Init(1, 10)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			pool.Acquire()
+		}()
+		go func() {
+			defer wg.Done()
+			pool.Release()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestAcquireContextDuringConcurrentRelease(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		pool := NewPool[int]()
+		pool.Init(1, 10)
+		pool.Acquire() // drain the only buffer so AcquireContext has to block
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := pool.AcquireContext(context.Background())
+			if err != nil && err != ErrPoolReleased {
+				t.Errorf("Expected ErrPoolReleased or success, got %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			pool.Release()
+		}()
+		wg.Wait()
+	}
+}
+
 func TestPutEdgeCases(t *testing.T) {
 	pool := NewPool[int]()
 	pool.Init(1, 10)
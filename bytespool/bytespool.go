@@ -0,0 +1,56 @@
+// Package bytespool wraps a buffpool.Buffer[byte] with the io.Reader and
+// io.Writer adapters callers otherwise have to hand-write to plug a pooled
+// buffer into things like gzip.Writer.Reset, bufio.NewReaderSize, or an
+// HTTP response body.
+package bytespool
+
+import (
+	"io"
+
+	"github.com/AaronFei/buffpool"
+)
+
+// Reader reads the valid portion of a Buffer[byte] sequentially.
+type Reader struct {
+	buf *buffpool.Buffer[byte]
+	pos int
+}
+
+// NewReader wraps buf for sequential reads of its valid data.
+func NewReader(buf *buffpool.Buffer[byte]) *Reader {
+	return &Reader{buf: buf}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	data := r.buf.GetValidData()
+	if r.pos >= len(data) {
+		return 0, io.EOF
+	}
+	n := copy(p, data[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+// Writer appends writes into a Buffer[byte]'s backing storage, advancing
+// its length as it goes. Write returns io.ErrShortWrite once the buffer's
+// capacity is exhausted; the buffer is fixed-size and never grows.
+type Writer struct {
+	buf *buffpool.Buffer[byte]
+}
+
+// NewWriter wraps buf for sequential writes starting at its current
+// length, so repeated writes append rather than overwrite.
+func NewWriter(buf *buffpool.Buffer[byte]) *Writer {
+	return &Writer{buf: buf}
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	full := w.buf.GetFullData()
+	start := w.buf.GetLength()
+	n := copy(full[start:], p)
+	w.buf.SetLength(start + n)
+	if n < len(p) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
@@ -0,0 +1,62 @@
+package bytespool
+
+import (
+	"bufio"
+	"io"
+	"testing"
+
+	"github.com/AaronFei/buffpool"
+)
+
+func TestWriterAndReader(t *testing.T) {
+	pool := buffpool.NewPool[byte]()
+	pool.Init(1, 32)
+	buf, _ := pool.Acquire()
+	defer buf.Release()
+
+	w := NewWriter(buf)
+	n, err := w.Write([]byte("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("Write failed: n=%d err=%v", n, err)
+	}
+
+	r := NewReader(buf)
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("Expected %q, got %q", "hello", got)
+	}
+}
+
+func TestWriterShortWriteOnFullBuffer(t *testing.T) {
+	pool := buffpool.NewPool[byte]()
+	pool.Init(1, 4)
+	buf, _ := pool.Acquire()
+	defer buf.Release()
+
+	w := NewWriter(buf)
+	n, err := w.Write([]byte("hello"))
+	if err != io.ErrShortWrite || n != 4 {
+		t.Errorf("Expected short write of 4 bytes, got n=%d err=%v", n, err)
+	}
+}
+
+func TestReaderWithBufio(t *testing.T) {
+	pool := buffpool.NewPool[byte]()
+	pool.Init(1, 32)
+	buf, _ := pool.Acquire()
+	defer buf.Release()
+
+	NewWriter(buf).Write([]byte("line one\n"))
+
+	br := bufio.NewReaderSize(NewReader(buf), 16)
+	line, err := br.ReadString('\n')
+	if err != nil {
+		t.Fatalf("ReadString failed: %v", err)
+	}
+	if line != "line one\n" {
+		t.Errorf("Expected %q, got %q", "line one\n", line)
+	}
+}
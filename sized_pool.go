@@ -0,0 +1,209 @@
+package buffpool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// discardOwner is the bufferOwner for one-off buffers handed out when a
+// requested size exceeds every configured class. Release on these buffers
+// just drops them for GC instead of returning them to any sub-pool.
+type discardOwner[T any] struct{}
+
+func (discardOwner[T]) put(*Buffer[T]) {}
+
+type classStats struct {
+	hits   int64
+	misses int64
+}
+
+// ClassStats is a point-in-time snapshot of one size class's usage,
+// returned by SizedPool.Stats so callers can tune their class boundaries.
+type ClassStats struct {
+	Size   int
+	Hits   int64
+	Misses int64
+}
+
+// SizedPoolStats is the snapshot returned by SizedPool.Stats.
+type SizedPoolStats struct {
+	Classes       []ClassStats
+	OversizeSkips int64
+}
+
+// SizedPool manages several Pool[T] sub-pools keyed by capacity class,
+// modeled on goleveldb's bucketed buffer pool. Acquire picks the smallest
+// class that can satisfy a requested size instead of handing out a single
+// fixed bufSize like Pool does.
+type SizedPool[T any] struct {
+	classes       []int
+	pools         []*Pool[T]
+	stats         []classStats
+	floor         int
+	allowOversize bool
+	oversizeSkips int64
+	isInitialized bool
+	isReleased    int32
+	mu            sync.Mutex
+}
+
+func NewSizedPool[T any]() *SizedPool[T] {
+	return &SizedPool[T]{
+		isInitialized: false,
+		isReleased:    0,
+	}
+}
+
+// Init configures the pool with a set of ascending size classes (e.g.
+// []int{64, 512, 4096, 32768, 262144}), the buffer count to pre-allocate
+// per class, and a floor below which requests are rounded up so a tiny
+// request can't pull a buffer from the largest class. If allowOversize is
+// true, a request bigger than the largest class gets a one-off, unpooled
+// buffer instead of failing outright.
+func (p *SizedPool[T]) Init(classes []int, bufCountPerClass, floor int, allowOversize bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if atomic.LoadInt32(&p.isReleased) == 1 {
+		return fmt.Errorf("pool has been released")
+	}
+
+	if len(classes) == 0 {
+		return fmt.Errorf("at least one size class is required")
+	}
+	if bufCountPerClass <= 0 {
+		return fmt.Errorf("invalid buffer count")
+	}
+	if floor < 0 {
+		return fmt.Errorf("invalid floor")
+	}
+
+	sorted := append([]int(nil), classes...)
+	sort.Ints(sorted)
+	for i, size := range sorted {
+		if size <= 0 {
+			return fmt.Errorf("invalid size class %d", size)
+		}
+		if i > 0 && sorted[i] == sorted[i-1] {
+			return fmt.Errorf("duplicate size class %d", size)
+		}
+	}
+
+	pools := make([]*Pool[T], len(sorted))
+	for i, size := range sorted {
+		pool := NewPool[T]()
+		if err := pool.Init(bufCountPerClass, size); err != nil {
+			return err
+		}
+		pools[i] = pool
+	}
+
+	p.classes = sorted
+	p.pools = pools
+	p.stats = make([]classStats, len(sorted))
+	p.floor = floor
+	p.allowOversize = allowOversize
+	p.oversizeSkips = 0
+	p.isInitialized = true
+	return nil
+}
+
+// classFor returns the index of the smallest class able to hold size, or
+// -1 if size exceeds every configured class.
+func (p *SizedPool[T]) classFor(size int) int {
+	if size < p.floor {
+		size = p.floor
+	}
+	for i, c := range p.classes {
+		if c >= size {
+			return i
+		}
+	}
+	return -1
+}
+
+// Acquire picks the smallest size class that can hold size and returns a
+// buffer from that class's sub-pool. Requests above the largest class are
+// served by a one-off, unpooled buffer when allowOversize is set, and fail
+// otherwise; either way they're counted by the oversize skip counter.
+func (p *SizedPool[T]) Acquire(size int) (*Buffer[T], bool) {
+	p.mu.Lock()
+	if atomic.LoadInt32(&p.isReleased) == 1 {
+		p.mu.Unlock()
+		return nil, false
+	}
+
+	idx := p.classFor(size)
+	if idx == -1 {
+		atomic.AddInt64(&p.oversizeSkips, 1)
+		allowOversize := p.allowOversize
+		p.mu.Unlock()
+		if !allowOversize {
+			return nil, false
+		}
+		return &Buffer[T]{
+			data:  make([]T, size),
+			pool:  discardOwner[T]{},
+			inUse: 1,
+		}, true
+	}
+	pool := p.pools[idx]
+	p.mu.Unlock()
+
+	// pool.Acquire() is independently synchronized on the sub-pool's own
+	// mutex-free channel, so classes don't serialize behind p.mu here -
+	// only the stats bookkeeping below needs it, to stay safe against a
+	// concurrent Release nilling p.stats.
+	buf, ok := pool.Acquire()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if atomic.LoadInt32(&p.isReleased) == 1 {
+		return buf, ok
+	}
+	if ok {
+		atomic.AddInt64(&p.stats[idx].hits, 1)
+	} else {
+		atomic.AddInt64(&p.stats[idx].misses, 1)
+	}
+	return buf, ok
+}
+
+// Stats returns a snapshot of per-class hit/miss counters plus the number
+// of requests that exceeded every configured class.
+func (p *SizedPool[T]) Stats() SizedPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	classes := make([]ClassStats, len(p.classes))
+	for i, size := range p.classes {
+		classes[i] = ClassStats{
+			Size:   size,
+			Hits:   atomic.LoadInt64(&p.stats[i].hits),
+			Misses: atomic.LoadInt64(&p.stats[i].misses),
+		}
+	}
+	return SizedPoolStats{
+		Classes:       classes,
+		OversizeSkips: atomic.LoadInt64(&p.oversizeSkips),
+	}
+}
+
+// Release tears down every sub-pool, freeing their buffers for GC.
+func (p *SizedPool[T]) Release() {
+	if atomic.CompareAndSwapInt32(&p.isReleased, 0, 1) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for _, pool := range p.pools {
+			pool.Release()
+		}
+
+		p.classes = nil
+		p.pools = nil
+		p.stats = nil
+		p.isInitialized = false
+	}
+}
@@ -0,0 +1,133 @@
+package buffpool
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSizedPoolInit(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	err := pool.Init([]int{512, 64, 4096}, 2, 32, false)
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if len(pool.classes) != 3 || pool.classes[0] != 64 || pool.classes[2] != 4096 {
+		t.Errorf("Expected sorted classes [64 512 4096], got %v", pool.classes)
+	}
+}
+
+func TestSizedPoolInitInvalid(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	if err := pool.Init(nil, 2, 0, false); err == nil {
+		t.Error("Expected error for empty class list, got nil")
+	}
+	if err := pool.Init([]int{64}, 0, 0, false); err == nil {
+		t.Error("Expected error for invalid buffer count, got nil")
+	}
+	if err := pool.Init([]int{64, 64}, 2, 0, false); err == nil {
+		t.Error("Expected error for duplicate size class, got nil")
+	}
+}
+
+func TestSizedPoolAcquireAndRelease(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	pool.Init([]int{64, 512, 4096}, 1, 0, false)
+
+	buf, ok := pool.Acquire(100)
+	if !ok {
+		t.Fatal("Failed to acquire buffer")
+	}
+	if cap(buf.GetFullData()) != 512 {
+		t.Errorf("Expected buffer from the 512 class, got cap %d", cap(buf.GetFullData()))
+	}
+
+	// The class's single buffer is now checked out, so a second request
+	// against the same class should miss.
+	if _, ok := pool.Acquire(100); ok {
+		t.Error("Expected second acquire against exhausted class to fail")
+	}
+
+	buf.Release()
+	if _, ok := pool.Acquire(100); !ok {
+		t.Error("Expected acquire to succeed after release")
+	}
+}
+
+func TestSizedPoolFloor(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	pool.Init([]int{64, 512, 4096}, 1, 128, false)
+
+	buf, ok := pool.Acquire(10)
+	if !ok {
+		t.Fatal("Failed to acquire buffer")
+	}
+	if cap(buf.GetFullData()) != 512 {
+		t.Errorf("Expected floor to round a 10-byte request up to the 512 class, got cap %d", cap(buf.GetFullData()))
+	}
+	buf.Release()
+}
+
+func TestSizedPoolOversize(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	pool.Init([]int{64, 512}, 1, 0, false)
+
+	if _, ok := pool.Acquire(4096); ok {
+		t.Error("Expected oversize request to fail when allowOversize is false")
+	}
+
+	pool.Release()
+	pool = NewSizedPool[byte]()
+	pool.Init([]int{64, 512}, 1, 0, true)
+
+	buf, ok := pool.Acquire(4096)
+	if !ok {
+		t.Fatal("Expected oversize request to succeed when allowOversize is true")
+	}
+	if cap(buf.GetFullData()) != 4096 {
+		t.Errorf("Expected a one-off buffer of size 4096, got cap %d", cap(buf.GetFullData()))
+	}
+	buf.Release() // should not panic even though it's not pooled
+
+	stats := pool.Stats()
+	if stats.OversizeSkips != 1 {
+		t.Errorf("Expected 1 oversize skip, got %d", stats.OversizeSkips)
+	}
+}
+
+func TestSizedPoolAcquireAndStatsDuringConcurrentRelease(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		pool := NewSizedPool[byte]()
+		pool.Init([]int{64, 512, 4096}, 4, 0, false)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			pool.Acquire(100)
+		}()
+		go func() {
+			defer wg.Done()
+			pool.Stats()
+		}()
+		go func() {
+			defer wg.Done()
+			pool.Release()
+		}()
+		wg.Wait()
+	}
+}
+
+func TestSizedPoolStats(t *testing.T) {
+	pool := NewSizedPool[byte]()
+	pool.Init([]int{64, 512}, 1, 0, false)
+
+	buf, _ := pool.Acquire(32)
+	pool.Acquire(32) // should miss, class exhausted
+
+	stats := pool.Stats()
+	if stats.Classes[0].Hits != 1 || stats.Classes[0].Misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss on the 64 class, got %+v", stats.Classes[0])
+	}
+
+	buf.Release()
+}